@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Chunk sizes are measured in words rather than tokens, since we have no
+// tokenizer on hand; a word is a reasonable proxy and keeps chunks in the
+// 1-2k token range the RAG use case asked for.
+const (
+	maxChunkWords = 800
+	overlapWords  = 80
+)
+
+// docChunk is one heading-bounded slice of a document, ready to be stored
+// in the chunks table.
+type docChunk struct {
+	headingPath string
+	anchor      string
+	content     string
+}
+
+var chunkHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(.+)$`)
+
+// chunkDoc splits a document's cleaned markdown content at heading
+// boundaries into docChunks, breadcrumbing each chunk's heading_path (e.g.
+// "Title / Section / Subsection") and the mkdocs-style slug anchor of its
+// nearest enclosing heading. Sections longer than maxChunkWords are
+// further split into overlapping chunks so a chunk never loses the
+// context at its boundary. Lines inside fenced code blocks (``` ... ```)
+// are never treated as heading boundaries, so a "#" comment in an embedded
+// code sample doesn't get mistaken for a real heading.
+func chunkDoc(title, content string) []docChunk {
+	headingPath := []string{title}
+	anchor := ""
+	anchors := make(map[string]int)
+
+	var chunks []docChunk
+	var buf []string
+
+	flush := func() {
+		text := strings.TrimSpace(strings.Join(buf, "\n"))
+		buf = nil
+		if text == "" {
+			return
+		}
+		hp := strings.Join(headingPath, " / ")
+		for _, part := range splitWords(text, maxChunkWords, overlapWords) {
+			chunks = append(chunks, docChunk{headingPath: hp, anchor: anchor, content: part})
+		}
+	}
+
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			buf = append(buf, line)
+			continue
+		}
+		m := chunkHeadingRe.FindStringSubmatch(line)
+		if inFence || m == nil {
+			buf = append(buf, line)
+			continue
+		}
+		flush()
+
+		level := len(m[1])
+		heading := strings.TrimSpace(m[2])
+		if level < len(headingPath) {
+			headingPath = headingPath[:level]
+		}
+		for len(headingPath) < level {
+			headingPath = append(headingPath, "")
+		}
+		headingPath = append(headingPath, heading)
+		anchor = uniqueAnchor(slugify(heading), anchors)
+	}
+	flush()
+
+	return chunks
+}
+
+var slugNonAlnumRe = regexp.MustCompile(`[^a-z0-9]+`)
+
+// slugify mirrors mkdocs' heading-id generation closely enough to link a
+// chunk back to its anchor on the rendered page: lower-case, non-alphanumeric
+// runs collapsed to a single hyphen, leading/trailing hyphens trimmed.
+func slugify(s string) string {
+	s = slugNonAlnumRe.ReplaceAllString(strings.ToLower(s), "-")
+	return strings.Trim(s, "-")
+}
+
+// uniqueAnchor disambiguates repeated headings within a document the way
+// mkdocs does, appending "-2", "-3", ... to the second and later use of the
+// same slug.
+func uniqueAnchor(slug string, seen map[string]int) string {
+	if slug == "" {
+		slug = "section"
+	}
+	seen[slug]++
+	if n := seen[slug]; n > 1 {
+		return fmt.Sprintf("%s-%d", slug, n)
+	}
+	return slug
+}
+
+// splitWords breaks content into chunks of at most maxWords words, each
+// chunk overlapping the previous by overlapWords words so that a model
+// reading one chunk retains some of the prior chunk's context.
+func splitWords(content string, maxWords, overlapWords int) []string {
+	words := strings.Fields(content)
+	if len(words) <= maxWords {
+		return []string{content}
+	}
+
+	step := maxWords - overlapWords
+	var parts []string
+	for start := 0; start < len(words); start += step {
+		end := start + maxWords
+		if end > len(words) {
+			end = len(words)
+		}
+		parts = append(parts, strings.Join(words[start:end], " "))
+		if end == len(words) {
+			break
+		}
+	}
+	return parts
+}
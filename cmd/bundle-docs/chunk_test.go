@@ -0,0 +1,124 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"simple words", "Getting Started", "getting-started"},
+		{"punctuation and glyphs collapse to one hyphen", "Iota (⍳)", "iota"},
+		{"leading and trailing punctuation trimmed", "  Hello, World!  ", "hello-world"},
+		{"already a slug", "already-a-slug", "already-a-slug"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slugify(tt.in); got != tt.want {
+				t.Errorf("slugify(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChunkDocHeadingBoundaries(t *testing.T) {
+	content := "Intro text before any heading.\n\n" +
+		"# Overview\n\n" +
+		"Overview body.\n\n" +
+		"## Details\n\n" +
+		"Details body.\n"
+
+	chunks := chunkDoc("Guide", content)
+
+	want := []struct {
+		headingPath string
+		anchor      string
+		contains    string
+	}{
+		{"Guide", "", "Intro text before any heading."},
+		{"Guide / Overview", "overview", "Overview body."},
+		{"Guide / Overview / Details", "details", "Details body."},
+	}
+	if len(chunks) != len(want) {
+		t.Fatalf("got %d chunks, want %d: %+v", len(chunks), len(want), chunks)
+	}
+	for i, w := range want {
+		c := chunks[i]
+		if c.headingPath != w.headingPath {
+			t.Errorf("chunk %d headingPath = %q, want %q", i, c.headingPath, w.headingPath)
+		}
+		if c.anchor != w.anchor {
+			t.Errorf("chunk %d anchor = %q, want %q", i, c.anchor, w.anchor)
+		}
+		if !strings.Contains(c.content, w.contains) {
+			t.Errorf("chunk %d content = %q, want to contain %q", i, c.content, w.contains)
+		}
+	}
+}
+
+func TestChunkDocDuplicateHeadingsGetDistinctAnchors(t *testing.T) {
+	content := "# Examples\n\nFirst.\n\n" +
+		"# Examples\n\nSecond.\n"
+
+	chunks := chunkDoc("Guide", content)
+	if len(chunks) != 2 {
+		t.Fatalf("got %d chunks, want 2: %+v", len(chunks), chunks)
+	}
+	if chunks[0].anchor != "examples" {
+		t.Errorf("first anchor = %q, want %q", chunks[0].anchor, "examples")
+	}
+	if chunks[1].anchor != "examples-2" {
+		t.Errorf("second anchor = %q, want %q", chunks[1].anchor, "examples-2")
+	}
+}
+
+func TestChunkDocIgnoresHeadingLikeLinesInFencedCode(t *testing.T) {
+	content := "Intro.\n\n" +
+		"```python\n" +
+		"# this is a code comment, not a heading\n" +
+		"print(1)\n" +
+		"```\n\n" +
+		"More text.\n"
+
+	chunks := chunkDoc("Guide", content)
+	if len(chunks) != 1 {
+		t.Fatalf("got %d chunks, want 1: %+v", len(chunks), chunks)
+	}
+	c := chunks[0]
+	if c.headingPath != "Guide" {
+		t.Errorf("headingPath = %q, want %q", c.headingPath, "Guide")
+	}
+	if c.anchor != "" {
+		t.Errorf("anchor = %q, want %q", c.anchor, "")
+	}
+	for _, want := range []string{"# this is a code comment, not a heading", "print(1)", "More text."} {
+		if !strings.Contains(c.content, want) {
+			t.Errorf("content = %q, want to contain %q", c.content, want)
+		}
+	}
+}
+
+func TestChunkDocSplitsOversizedSectionsWithOverlap(t *testing.T) {
+	words := make([]string, maxChunkWords+100)
+	for i := range words {
+		words[i] = "word"
+	}
+	content := "# Big Section\n\n" + strings.Join(words, " ") + "\n"
+
+	chunks := chunkDoc("Guide", content)
+	if len(chunks) < 2 {
+		t.Fatalf("expected an oversized section to be split into multiple chunks, got %d", len(chunks))
+	}
+	for _, c := range chunks {
+		if c.anchor != "big-section" {
+			t.Errorf("chunk anchor = %q, want %q", c.anchor, "big-section")
+		}
+		if n := len(strings.Fields(c.content)); n > maxChunkWords {
+			t.Errorf("chunk has %d words, want <= %d", n, maxChunkWords)
+		}
+	}
+}
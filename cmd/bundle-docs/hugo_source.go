@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"io/fs"
+	"log"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// hugoSource enumerates a Hugo-style directory of markdown with YAML
+// front-matter, walking content/ (or the directory root, if there's no
+// content/ subdirectory) and ordering pages within each section by their
+// front-matter weight.
+type hugoSource struct {
+	dir string
+}
+
+func (h *hugoSource) Fetch(ctx context.Context) (fs.FS, error) {
+	return os.DirFS(h.dir), nil
+}
+
+type hugoFrontMatter struct {
+	Title  string `yaml:"title"`
+	Weight int    `yaml:"weight"`
+}
+
+type hugoPage struct {
+	relPath string
+	meta    hugoFrontMatter
+	body    string
+}
+
+func (h *hugoSource) Enumerate(fsys fs.FS) ([]docEntry, error) {
+	root := "content"
+	if _, err := fs.Stat(fsys, root); err != nil {
+		root = "."
+	}
+
+	var pages []hugoPage
+	err := fs.WalkDir(fsys, root, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(p, ".md") {
+			return nil
+		}
+		raw, err := fs.ReadFile(fsys, p)
+		if err != nil {
+			return err
+		}
+		fm, body := splitFrontMatter(raw)
+		var meta hugoFrontMatter
+		if len(fm) > 0 {
+			if err := yaml.Unmarshal(fm, &meta); err != nil {
+				log.Printf("warning: %s: front matter: %v", p, err)
+			}
+		}
+		pages = append(pages, hugoPage{relPath: p, meta: meta, body: string(body)})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(pages, func(i, j int) bool {
+		if pages[i].meta.Weight != pages[j].meta.Weight {
+			return pages[i].meta.Weight < pages[j].meta.Weight
+		}
+		return pages[i].relPath < pages[j].relPath
+	})
+
+	docs := make([]docEntry, 0, len(pages))
+	for _, pg := range pages {
+		title := pg.meta.Title
+		if title == "" {
+			title = titleFromSlug(strings.TrimSuffix(path.Base(pg.relPath), ".md"))
+		}
+		docs = append(docs, docEntry{
+			path:    hugoNavPath(root, pg.relPath, title),
+			file:    pg.relPath,
+			title:   title,
+			content: pg.body,
+			source:  "hugo",
+		})
+	}
+	return docs, nil
+}
+
+// hugoNavPath builds a breadcrumb from a content-relative path's directory
+// segments plus the page title, e.g. "content/guides/setup/_index.md" with
+// title "Setup" → "Guides / Setup".
+func hugoNavPath(root, relPath, title string) string {
+	rel := strings.TrimPrefix(relPath, root+"/")
+	dir := path.Dir(rel)
+	base := strings.TrimSuffix(path.Base(rel), ".md")
+
+	var segs []string
+	if dir != "." {
+		for _, s := range strings.Split(dir, "/") {
+			segs = append(segs, titleFromSlug(s))
+		}
+	}
+	if base != "_index" && base != "index" {
+		segs = append(segs, title)
+	}
+	if len(segs) == 0 {
+		return title
+	}
+	return strings.Join(segs, " / ")
+}
+
+// titleFromSlug title-cases a hyphenated path segment, e.g.
+// "getting-started" → "Getting Started".
+func titleFromSlug(s string) string {
+	words := strings.Split(s, "-")
+	for i, w := range words {
+		if len(w) > 0 {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+	return strings.Join(words, " ")
+}
+
+// splitFrontMatter separates a leading "---\n...\n---" YAML front-matter
+// block from the rest of a Hugo content file.
+func splitFrontMatter(raw []byte) (frontMatter, body []byte) {
+	s := string(raw)
+	if !strings.HasPrefix(s, "---") {
+		return nil, raw
+	}
+	end := strings.Index(s[3:], "\n---")
+	if end < 0 {
+		return nil, raw
+	}
+	fm := s[3 : 3+end]
+	rest := strings.TrimLeft(s[3+end+4:], "\n")
+	return []byte(fm), []byte(rest)
+}
@@ -0,0 +1,204 @@
+// bundle-docs ingests a documentation corpus through a pluggable Source
+// and produces a sqlite3 database of all content, keyed by navigation
+// path, searchable via FTS5.
+//
+// The default -source is "mkdocs", which clones the Dyalog documentation
+// repo and parses its mkdocs monorepo structure. With -cache <dir>, the
+// clone and per-file parse results are persisted across runs so rebuilds
+// only fetch new commits and re-parse files whose git blob SHA has
+// changed. Other sources ("hugo", "sphinx", "sitemap") enumerate a local
+// directory instead; see their flags below.
+//
+//	go build -tags "fts5" -o bundle-docs ./cmd/bundle-docs
+package main
+
+import (
+	"context"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	output := flag.String("o", "dyalog-docs.db", "output database path")
+	sourceName := flag.String("source", "mkdocs", "ingestion source: mkdocs, hugo, sphinx, sitemap")
+	repo := flag.String("repo", "git@github.com:Dyalog/documentation.git", "documentation repo URL (mkdocs source)")
+	helpURLs := flag.String("help-urls", "symbol-urls.json", "path to symbol-urls.json (mkdocs source)")
+	keep := flag.Bool("keep", false, "keep cloned repo (print path, mkdocs source)")
+	cacheDir := flag.String("cache", "", "directory holding a persistent bare clone and parse cache (mkdocs source)")
+	dir := flag.String("dir", "", "local directory to enumerate (hugo, sphinx, sitemap sources)")
+	flag.Parse()
+
+	src, err := newSource(*sourceName, sourceOpts{
+		repo:     *repo,
+		helpURLs: *helpURLs,
+		cacheDir: *cacheDir,
+		keep:     *keep,
+		dir:      *dir,
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	if m, ok := src.(*mkdocsSource); ok {
+		defer m.cleanup()
+	}
+
+	fsys, err := src.Fetch(context.Background())
+	if err != nil {
+		log.Fatalf("fetch: %v", err)
+	}
+
+	docs, err := src.Enumerate(fsys)
+	if err != nil {
+		log.Fatalf("enumerate: %v", err)
+	}
+	fmt.Fprintf(os.Stderr, "Found %d documents\n", len(docs))
+
+	// Write database
+	os.Remove(*output)
+	db, err := sql.Open("sqlite3", *output)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Exec(`
+		CREATE TABLE docs (
+			path TEXT PRIMARY KEY,
+			file TEXT NOT NULL,
+			title TEXT NOT NULL,
+			keywords TEXT NOT NULL DEFAULT '',
+			content TEXT NOT NULL,
+			exclude INTEGER NOT NULL DEFAULT 0,
+			source TEXT NOT NULL DEFAULT 'mkdocs'
+		);
+		CREATE VIRTUAL TABLE docs_fts USING fts5(
+			path,
+			title,
+			keywords,
+			content,
+			content='docs',
+			content_rowid='rowid'
+		);
+		CREATE TRIGGER docs_ai AFTER INSERT ON docs BEGIN
+			INSERT INTO docs_fts(rowid, path, title, keywords, content)
+			VALUES (NEW.rowid, NEW.path, NEW.title, NEW.keywords, NEW.content);
+		END;
+		CREATE TABLE help_urls (
+			symbol TEXT PRIMARY KEY,
+			path TEXT NOT NULL
+		);
+		CREATE TABLE chunks (
+			chunk_id INTEGER PRIMARY KEY,
+			doc_rowid INTEGER NOT NULL REFERENCES docs(rowid),
+			heading_path TEXT NOT NULL,
+			anchor TEXT NOT NULL,
+			content TEXT NOT NULL
+		);
+		CREATE VIRTUAL TABLE chunks_fts USING fts5(
+			heading_path,
+			content,
+			content='chunks',
+			content_rowid='chunk_id'
+		);
+		CREATE TRIGGER chunks_ai AFTER INSERT ON chunks BEGIN
+			INSERT INTO chunks_fts(rowid, heading_path, content)
+			VALUES (NEW.chunk_id, NEW.heading_path, NEW.content);
+		END;
+	`); err != nil {
+		log.Fatal(err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		log.Fatal(err)
+	}
+	ins, err := tx.Prepare("INSERT OR IGNORE INTO docs (path, file, title, keywords, content, exclude, source) VALUES (?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		log.Fatal(err)
+	}
+	chunkIns, err := tx.Prepare("INSERT INTO chunks (doc_rowid, heading_path, anchor, content) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, d := range docs {
+		exclude := 0
+		if d.exclude {
+			exclude = 1
+		}
+		res, err := ins.Exec(d.path, d.file, d.title, d.keywords, d.content, exclude, d.source)
+		if err != nil {
+			log.Printf("insert %s: %v", d.path, err)
+			continue
+		}
+		if affected, _ := res.RowsAffected(); affected == 0 {
+			continue // duplicate path, ignored by the insert above
+		}
+		rowid, err := res.LastInsertId()
+		if err != nil {
+			log.Printf("insert %s: %v", d.path, err)
+			continue
+		}
+		for _, c := range chunkDoc(d.title, d.content) {
+			if _, err := chunkIns.Exec(rowid, c.headingPath, c.anchor, c.content); err != nil {
+				log.Printf("insert chunk %s#%s: %v", d.path, c.anchor, err)
+			}
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		log.Fatal(err)
+	}
+
+	if m, ok := src.(*mkdocsSource); ok {
+		if err := m.matchHelpURLs(db, fsys, docs); err != nil {
+			log.Printf("warning: help_urls: %v", err)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Wrote %s\n", *output)
+}
+
+// sourceOpts bundles the flags relevant to one or more Source
+// implementations; each implementation only reads the fields it needs.
+type sourceOpts struct {
+	repo     string
+	helpURLs string
+	cacheDir string
+	keep     bool
+	dir      string
+}
+
+// newSource constructs the Source named by sourceName.
+func newSource(sourceName string, opts sourceOpts) (Source, error) {
+	switch sourceName {
+	case "mkdocs":
+		return &mkdocsSource{
+			repo:     opts.repo,
+			helpURLs: opts.helpURLs,
+			cacheDir: opts.cacheDir,
+			keep:     opts.keep,
+		}, nil
+	case "hugo":
+		if opts.dir == "" {
+			return nil, fmt.Errorf("-source hugo requires -dir")
+		}
+		return &hugoSource{dir: opts.dir}, nil
+	case "sphinx":
+		if opts.dir == "" {
+			return nil, fmt.Errorf("-source sphinx requires -dir")
+		}
+		return &sphinxSource{dir: opts.dir}, nil
+	case "sitemap":
+		if opts.dir == "" {
+			return nil, fmt.Errorf("-source sitemap requires -dir")
+		}
+		return &sitemapSource{dir: opts.dir}, nil
+	default:
+		return nil, fmt.Errorf("unknown -source %q", sourceName)
+	}
+}
@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+	"golang.org/x/net/html"
+	"gopkg.in/yaml.v3"
+)
+
+// mkdocsFrontMatter captures the mkdocs-material "search" front-matter
+// block, which can carry extra keywords for a page beyond its visible text.
+type mkdocsFrontMatter struct {
+	Search struct {
+		Keywords []string `yaml:"keywords"`
+	} `yaml:"search"`
+}
+
+// extractTitleAndClean parses raw as CommonMark with goldmark, extracting
+// the first heading (at any level) as the title and harvesting keywords
+// from hidden `<div style="display:none">` islands and the mkdocs-material
+// "search.keywords" front-matter block. It returns the cleaned markdown
+// with those hidden-keyword divs stripped and the remaining embedded HTML
+// converted to markdown (h1-h3, kbd, strong, br) or stripped to its text
+// (every other tag), leaving the markdown outside HTML islands - including
+// admonitions and "--8<--" snippet includes - untouched.
+func extractTitleAndClean(raw []byte) (title, keywords, content string) {
+	front, body := splitFrontMatter(raw)
+
+	var kws []string
+	if len(front) > 0 {
+		var fm mkdocsFrontMatter
+		if err := yaml.Unmarshal(front, &fm); err == nil {
+			kws = append(kws, fm.Search.Keywords...)
+		}
+	}
+
+	doc := goldmark.DefaultParser().Parse(text.NewReader(body))
+
+	var splices []splice
+	_ = ast.Walk(doc, func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n.Kind() {
+		case ast.KindHeading:
+			if title == "" {
+				title = extractText(n, body)
+			}
+		case ast.KindHTMLBlock, ast.KindRawHTML:
+			start, end, frag := nodeSpan(n, body)
+			if frag == nil {
+				return ast.WalkContinue, nil
+			}
+			repl, fragKeywords, fragTitle := cleanHTMLFragment(frag)
+			if fragKeywords != "" {
+				kws = append(kws, fragKeywords)
+			}
+			if title == "" && fragTitle != "" {
+				title = fragTitle
+			}
+			splices = append(splices, splice{start: start, end: end, repl: repl})
+		}
+		return ast.WalkContinue, nil
+	})
+
+	return title, strings.Join(kws, " "), string(applySplices(body, splices))
+}
+
+// extractText concatenates the text segments of n's children, as plain text
+// with no markdown emphasis markers.
+func extractText(n ast.Node, source []byte) string {
+	var buf bytes.Buffer
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		if t, ok := c.(*ast.Text); ok {
+			buf.Write(t.Segment.Value(source))
+		} else {
+			buf.WriteString(extractText(c, source))
+		}
+	}
+	return strings.TrimSpace(buf.String())
+}
+
+// nodeSpan returns the byte range n occupies in source and its raw bytes.
+// HTMLBlock exposes its lines via Lines(); RawHTML (an inline node) carries
+// its own Segments field instead.
+func nodeSpan(n ast.Node, source []byte) (start, end int, frag []byte) {
+	var segs *text.Segments
+	switch v := n.(type) {
+	case *ast.HTMLBlock:
+		segs = v.Lines()
+	case *ast.RawHTML:
+		segs = v.Segments
+	default:
+		return 0, 0, nil
+	}
+	if segs.Len() == 0 {
+		return 0, 0, nil
+	}
+	first := segs.At(0)
+	last := segs.At(segs.Len() - 1)
+	start, end = first.Start, last.Stop
+	return start, end, source[start:end]
+}
+
+// splice marks a [start, end) byte range in the original source to be
+// replaced with repl.
+type splice struct {
+	start, end int
+	repl       string
+}
+
+// applySplices rewrites raw, replacing each splice's range with its
+// replacement text. Splices must not overlap; they are applied in
+// descending start order so earlier offsets stay valid as later ones edit
+// the slice.
+func applySplices(raw []byte, splices []splice) []byte {
+	sort.Slice(splices, func(i, j int) bool { return splices[i].start > splices[j].start })
+	out := append([]byte(nil), raw...)
+	for _, s := range splices {
+		out = append(out[:s.start], append([]byte(s.repl), out[s.end:]...)...)
+	}
+	return out
+}
+
+// cleanHTMLFragment tokenizes an embedded HTML island and returns its
+// markdown replacement, any keywords found in hidden divs
+// (style="display:none"), and the plain text of the first h1/h2/h3 found,
+// if any, as a title candidate. h1-h3 become heading markers, kbd/strong/br
+// get their markdown equivalents, and all other tags (span, sup, visible
+// divs and the rest) are stripped, leaving their text content in place.
+func cleanHTMLFragment(frag []byte) (replacement, keywords, heading string) {
+	var out, kw, h bytes.Buffer
+	hiddenDepth := -1 // depth of the hidden div, while inside one; -1 when not hidden
+	depth := 0
+	headingTag := ""  // h1/h2/h3 tag currently open, "" if none
+	headingDone := false
+
+	z := html.NewTokenizer(bytes.NewReader(frag))
+	for {
+		tt := z.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+		tok := z.Token()
+
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			switch tok.Data {
+			case "div":
+				hidden := isHiddenDiv(tok)
+				depth++
+				if hidden && hiddenDepth == -1 {
+					hiddenDepth = depth
+				}
+			case "h1", "h2", "h3":
+				writeText(&out, &kw, hiddenDepth, strings.Repeat("#", int(tok.Data[1]-'0'))+" ")
+				if !headingDone && headingTag == "" {
+					headingTag = tok.Data
+				}
+			case "kbd":
+				writeText(&out, &kw, hiddenDepth, "`")
+			case "strong":
+				writeText(&out, &kw, hiddenDepth, "**")
+			case "br":
+				writeText(&out, &kw, hiddenDepth, "\n")
+			}
+			if tt == html.SelfClosingTagToken && tok.Data == "div" {
+				depth--
+				if hiddenDepth == depth+1 {
+					hiddenDepth = -1
+				}
+			}
+		case html.EndTagToken:
+			switch tok.Data {
+			case "div":
+				if hiddenDepth == depth {
+					hiddenDepth = -1
+				}
+				depth--
+			case "h1", "h2", "h3":
+				if tok.Data == headingTag {
+					headingTag = ""
+					headingDone = true
+				}
+			case "kbd":
+				writeText(&out, &kw, hiddenDepth, "`")
+			case "strong":
+				writeText(&out, &kw, hiddenDepth, "**")
+			}
+		case html.TextToken:
+			writeText(&out, &kw, hiddenDepth, tok.Data)
+			if headingTag != "" {
+				h.WriteString(tok.Data)
+			}
+		}
+	}
+	return out.String(), strings.TrimSpace(kw.String()), strings.TrimSpace(h.String())
+}
+
+// writeText appends s to out, unless hiddenDepth indicates we are inside a
+// hidden div, in which case it is appended to kw (the harvested keywords)
+// instead.
+func writeText(out, kw *bytes.Buffer, hiddenDepth int, s string) {
+	if hiddenDepth >= 0 {
+		kw.WriteString(s)
+		return
+	}
+	out.WriteString(s)
+}
+
+// isHiddenDiv reports whether tok is a <div> whose style attribute
+// contains "display:none" (whitespace around the colon is ignored).
+func isHiddenDiv(tok html.Token) bool {
+	for _, attr := range tok.Attr {
+		if attr.Key != "style" {
+			continue
+		}
+		style := strings.ToLower(strings.Join(strings.Fields(attr.Val), ""))
+		if strings.Contains(style, "display:none") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractTitleAndClean(t *testing.T) {
+	tests := []struct {
+		name         string
+		raw          string
+		wantTitle    string
+		wantKeywords []string // each must appear in the returned keywords
+		wantContains []string // each must appear in the returned content
+		wantExcludes []string // each must NOT appear in the returned content
+	}{
+		{
+			name: "first heading wins regardless of level",
+			raw: "## Getting Started\n\n" +
+				"Welcome to the guide.\n",
+			wantTitle:    "Getting Started",
+			wantContains: []string{"Welcome to the guide."},
+		},
+		{
+			name: "hidden div harvested as keywords and stripped from content",
+			raw: "# Iota\n\n" +
+				`<div style="display: none">synonym alias</div>` + "\n\n" +
+				"Returns the index generator.\n",
+			wantTitle:    "Iota",
+			wantKeywords: []string{"synonym", "alias"},
+			wantContains: []string{"Returns the index generator."},
+			wantExcludes: []string{"synonym", "alias", "display"},
+		},
+		{
+			name: "mkdocs-material search.keywords front matter is merged in",
+			raw: "---\n" +
+				"search:\n" +
+				"  keywords:\n" +
+				"    - rho\n" +
+				"    - reshape\n" +
+				"---\n" +
+				"# Reshape\n\n" +
+				"Reshapes an array.\n",
+			wantTitle:    "Reshape",
+			wantKeywords: []string{"rho", "reshape"},
+			wantContains: []string{"Reshapes an array."},
+		},
+		{
+			name: "raw h1 island is captured as the title",
+			raw: "<h1>HTML Title</h1>\n\n" +
+				"Some body text.\n",
+			wantTitle:    "HTML Title",
+			wantContains: []string{"# HTML Title", "Some body text."},
+		},
+		{
+			name: "inline kbd/strong/br converted to markdown",
+			raw: "# Shortcuts\n\n" +
+				"Press <kbd>Enter</kbd> to confirm, then <strong>submit</strong>.<br>Done.\n",
+			wantTitle:    "Shortcuts",
+			wantContains: []string{"`Enter`", "**submit**"},
+			wantExcludes: []string{"<kbd>", "<strong>", "<br>"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			title, keywords, content := extractTitleAndClean([]byte(tt.raw))
+
+			if title != tt.wantTitle {
+				t.Errorf("title = %q, want %q", title, tt.wantTitle)
+			}
+			for _, kw := range tt.wantKeywords {
+				if !strings.Contains(keywords, kw) {
+					t.Errorf("keywords = %q, want to contain %q", keywords, kw)
+				}
+			}
+			for _, s := range tt.wantContains {
+				if !strings.Contains(content, s) {
+					t.Errorf("content = %q, want to contain %q", content, s)
+				}
+			}
+			for _, s := range tt.wantExcludes {
+				if strings.Contains(content, s) {
+					t.Errorf("content = %q, want to NOT contain %q", content, s)
+				}
+			}
+		})
+	}
+}
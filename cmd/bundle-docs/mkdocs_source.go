@@ -0,0 +1,707 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// mkdocsConfig represents the parts of mkdocs.yml we care about.
+type mkdocsConfig struct {
+	SiteName string      `yaml:"site_name"`
+	DocsDir  string      `yaml:"docs_dir"`
+	Nav      []yaml.Node `yaml:"nav"`
+}
+
+// mkdocsSource is the original bundle-docs ingestion path: it clones the
+// Dyalog documentation mkdocs monorepo (optionally through a persistent
+// -cache mirror) and walks its nav tree, then separately resolves
+// help_urls.h symbol mappings, adding disambiguation pages that exist in
+// the repo but aren't reachable from the nav.
+type mkdocsSource struct {
+	repo     string
+	helpURLs string
+	cacheDir string
+	keep     bool
+
+	tmpDir   string
+	cache    *parseCache
+	blobSHAs map[string]string
+}
+
+func (m *mkdocsSource) Fetch(ctx context.Context) (fs.FS, error) {
+	tmpDir, err := os.MkdirTemp("", "dyalog-docs-*")
+	if err != nil {
+		return nil, err
+	}
+	m.tmpDir = tmpDir
+
+	if m.cacheDir != "" {
+		if err := updateMirror(m.cacheDir, m.repo); err != nil {
+			return nil, fmt.Errorf("updating cache: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "Cloning from cache %s...\n", mirrorDir(m.cacheDir))
+		cmd := exec.Command("git", "clone", "--branch=main", "--single-branch", mirrorDir(m.cacheDir), tmpDir)
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("git clone from cache: %w", err)
+		}
+
+		cache, err := openParseCache(filepath.Join(m.cacheDir, "parse-cache.db"))
+		if err != nil {
+			return nil, fmt.Errorf("opening parse cache: %w", err)
+		}
+		m.cache = cache
+	} else {
+		fmt.Fprintf(os.Stderr, "Cloning %s...\n", m.repo)
+		cmd := exec.Command("git", "clone", "--depth=1", "--branch=main", "--single-branch", m.repo, tmpDir)
+		cmd.Stderr = os.Stderr
+		if err := cmd.Run(); err != nil {
+			return nil, fmt.Errorf("git clone: %w", err)
+		}
+	}
+	if m.keep {
+		fmt.Fprintf(os.Stderr, "Repo cloned to: %s\n", tmpDir)
+	}
+
+	blobSHAs, err := gitBlobSHAs(tmpDir)
+	if err != nil {
+		log.Printf("warning: blob SHAs unavailable, caching disabled for this run: %v", err)
+		blobSHAs = nil
+	}
+	m.blobSHAs = blobSHAs
+
+	return os.DirFS(tmpDir), nil
+}
+
+// cleanup removes the temporary clone and closes the parse cache. Callers
+// that didn't ask to -keep the clone should defer this after Fetch.
+func (m *mkdocsSource) cleanup() {
+	if m.cache != nil {
+		m.cache.Close()
+	}
+	if !m.keep && m.tmpDir != "" {
+		os.RemoveAll(m.tmpDir)
+	}
+}
+
+func (m *mkdocsSource) Enumerate(fsys fs.FS) ([]docEntry, error) {
+	cfg, err := parseMkdocs(fsys, "mkdocs.yml")
+	if err != nil {
+		return nil, fmt.Errorf("parsing mkdocs.yml: %w", err)
+	}
+
+	docsDir := cfg.DocsDir
+	if docsDir == "" {
+		docsDir = "docs"
+	}
+
+	var docs []docEntry
+	walkNav(fsys, cfg.Nav, docsDir, nil, &docs, m.cache, m.blobSHAs)
+	return docs, nil
+}
+
+// matchHelpURLs resolves help_urls.h symbol-to-URL mappings against docs,
+// adding any disambiguation pages referenced by help_urls.h that aren't
+// reachable from the mkdocs nav, then writes the symbol -> path mapping
+// into the help_urls table.
+func (m *mkdocsSource) matchHelpURLs(db *sql.DB, fsys fs.FS, docs []docEntry) error {
+	if m.helpURLs == "" {
+		return nil
+	}
+	entries, err := parseSymbolURLs(m.helpURLs)
+	if err != nil {
+		return fmt.Errorf("help_urls: %w", err)
+	}
+
+	helpURLsChanged, err := m.cache.helpURLsChanged(m.helpURLs)
+	if err != nil {
+		log.Printf("warning: checking help_urls cache: %v", err)
+		helpURLsChanged = true
+	} else if !helpURLsChanged {
+		fmt.Fprintf(os.Stderr, "help_urls.h unchanged since last build\n")
+	}
+
+	// Build file-to-path index for help_urls matching
+	fileIndex := make(map[string]string) // normalized file path → nav path
+	for _, d := range docs {
+		// Normalize: strip subsite prefix to get just the doc-relative path
+		// e.g. "language-reference-guide/docs/symbols/iota.md" → "language-reference-guide/symbols/iota"
+		norm := normalizeFilePath(d.file)
+		fileIndex[norm] = d.path
+	}
+
+	// First pass: find unmatched URLs and try to add their files to the docs
+	// table. These are disambiguation pages (e.g. symbols/iota) referenced
+	// by help_urls.h but not in the mkdocs nav. When help_urls.h itself is
+	// unchanged, a URL whose disambiguation page we resolved on a previous
+	// build is served from the cache without touching the filesystem at
+	// all, unless the target file's blob SHA has since changed - in which
+	// case it's re-resolved via findHelpFile like any new URL.
+	added := 0
+	tx2, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	docIns, err := tx2.Prepare("INSERT OR IGNORE INTO docs (path, file, title, keywords, content, exclude, source) VALUES (?, ?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if _, ok := matchHelpURL(e.url, fileIndex); ok {
+			continue // already in docs
+		}
+
+		var navPath, filePath, title, keywords, content string
+		ok := false
+		if !helpURLsChanged {
+			navPath, filePath, title, keywords, content, ok = m.cache.getHelpDoc(e.url, m.blobSHAs)
+		}
+		if !ok {
+			navPath, filePath, title, keywords, content, ok = findHelpFile(fsys, e.url, m.cache, m.blobSHAs)
+			if !ok {
+				continue
+			}
+			if err := m.cache.putHelpDoc(e.url, navPath, filePath, m.blobSHAs[filePath], title, keywords, content); err != nil {
+				log.Printf("warning: caching help doc for %s: %v", e.url, err)
+			}
+		}
+
+		docIns.Exec(navPath, filePath, title, keywords, content, 1, "mkdocs") // exclude=1 for disambiguation pages
+		fileIndex[normalizeFilePath(filePath)] = navPath
+		added++
+	}
+	if err := tx2.Commit(); err != nil {
+		return err
+	}
+	if added > 0 {
+		fmt.Fprintf(os.Stderr, "Added %d disambiguation pages from help_urls.h\n", added)
+	}
+
+	// Second pass: now match all help URLs to docs
+	matched := 0
+	tx3, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	hins, err := tx3.Prepare("INSERT OR IGNORE INTO help_urls (symbol, path) VALUES (?, ?)")
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if navPath, ok := matchHelpURL(e.url, fileIndex); ok {
+			hins.Exec(e.symbol, navPath)
+			matched++
+		}
+	}
+	if err := tx3.Commit(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Help URLs: %d parsed, %d matched to docs\n", len(entries), matched)
+
+	if err := m.cache.recordHelpURLs(m.helpURLs); err != nil {
+		log.Printf("warning: recording help_urls cache: %v", err)
+	}
+	return nil
+}
+
+func parseMkdocs(fsys fs.FS, name string) (*mkdocsConfig, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	var cfg mkdocsConfig
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	return &cfg, nil
+}
+
+// walkNav recursively traverses a mkdocs nav structure.
+// docsDir is the docs directory for the current site, relative to fsys.
+// breadcrumb is the current nav path prefix.
+// cache and blobSHAs support incremental rebuilds: blobSHAs maps a
+// repo-relative file path to its current git blob SHA, and cache is
+// consulted (and populated) so files whose blob SHA hasn't changed since
+// the last build are not re-parsed. Both may be nil to disable caching.
+func walkNav(fsys fs.FS, nodes []yaml.Node, docsDir string, breadcrumb []string, out *[]docEntry, cache *parseCache, blobSHAs map[string]string) {
+	for i := range nodes {
+		walkNavNode(fsys, &nodes[i], docsDir, breadcrumb, out, cache, blobSHAs)
+	}
+}
+
+func walkNavNode(fsys fs.FS, node *yaml.Node, docsDir string, breadcrumb []string, out *[]docEntry, cache *parseCache, blobSHAs map[string]string) {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		// Bare string: "index.md" or "some-file.md"
+		addDoc(fsys, node.Value, docsDir, breadcrumb, out, cache, blobSHAs)
+
+	case yaml.MappingNode:
+		// Key-value pairs: {"Title": "file.md"} or {"Title": [...]}
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			val := node.Content[i+1]
+			title := key.Value
+
+			switch val.Kind {
+			case yaml.ScalarNode:
+				value := val.Value
+				if strings.HasPrefix(value, "!include ") {
+					handleInclude(fsys, value, title, breadcrumb, out, cache, blobSHAs)
+				} else {
+					p := append(breadcrumb, title)
+					addDoc(fsys, value, docsDir, p, out, cache, blobSHAs)
+				}
+			case yaml.SequenceNode:
+				// Nested section
+				p := append(breadcrumb, title)
+				for j := range val.Content {
+					walkNavNode(fsys, val.Content[j], docsDir, p, out, cache, blobSHAs)
+				}
+			case yaml.MappingNode:
+				p := append(breadcrumb, title)
+				walkNavNode(fsys, val, docsDir, p, out, cache, blobSHAs)
+			}
+		}
+
+	case yaml.SequenceNode:
+		for i := range node.Content {
+			walkNavNode(fsys, node.Content[i], docsDir, breadcrumb, out, cache, blobSHAs)
+		}
+	}
+}
+
+func handleInclude(fsys fs.FS, value, parentTitle string, breadcrumb []string, out *[]docEntry, cache *parseCache, blobSHAs map[string]string) {
+	// value is like "!include ./subsite/mkdocs.yml"
+	relPath := strings.TrimPrefix(value, "!include ")
+	relPath = path.Clean(strings.TrimSpace(relPath))
+
+	cfg, err := parseMkdocs(fsys, relPath)
+	if err != nil {
+		log.Printf("warning: include %s: %v", relPath, err)
+		return
+	}
+
+	subsiteDir := path.Dir(relPath)
+	docsDir := cfg.DocsDir
+	if docsDir == "" {
+		docsDir = "docs"
+	}
+	absDocsDir := path.Join(subsiteDir, docsDir)
+
+	// Build breadcrumb: parent title only; site_name is not added
+	p := append(breadcrumb, parentTitle)
+	if cfg.SiteName != "" && cfg.SiteName != parentTitle {
+		// site_name is typically the same as parentTitle; avoid duplication
+	}
+
+	walkNav(fsys, cfg.Nav, absDocsDir, p, out, cache, blobSHAs)
+}
+
+func addDoc(fsys fs.FS, mdPath, docsDir string, breadcrumb []string, out *[]docEntry, cache *parseCache, blobSHAs map[string]string) {
+	if !strings.HasSuffix(mdPath, ".md") {
+		return
+	}
+	relFile := path.Join(docsDir, mdPath)
+
+	title, keywords, content, ok := parseFileCached(fsys, relFile, cache, blobSHAs)
+	if !ok {
+		return
+	}
+
+	navPath := strings.Join(breadcrumb, " / ")
+	if navPath == "" {
+		navPath = mdPath
+	}
+
+	// Use last breadcrumb segment as fallback title
+	if title == "" && len(breadcrumb) > 0 {
+		title = breadcrumb[len(breadcrumb)-1]
+	}
+
+	*out = append(*out, docEntry{
+		path:     navPath,
+		file:     relFile,
+		title:    title,
+		keywords: keywords,
+		content:  content,
+		source:   "mkdocs",
+	})
+}
+
+// parseFileCached reads and parses the markdown file at relFile (a path
+// within fsys), reusing a previously cached (title, keywords, content)
+// result when the file's blob SHA (relFile looked up in blobSHAs) matches
+// what was cached for relFile. Returns ok=false if the file cannot be read.
+func parseFileCached(fsys fs.FS, relFile string, cache *parseCache, blobSHAs map[string]string) (title, keywords, content string, ok bool) {
+	sha := blobSHAs[relFile]
+	if sha != "" {
+		if t, k, c, hit := cache.get(relFile, sha); hit {
+			return t, k, c, true
+		}
+	}
+
+	raw, err := fs.ReadFile(fsys, relFile)
+	if err != nil {
+		log.Printf("warning: %s: %v", relFile, err)
+		return "", "", "", false
+	}
+	title, keywords, content = extractTitleAndClean(raw)
+
+	if sha != "" {
+		cache.put(relFile, sha, title, keywords, content)
+	}
+	return title, keywords, content, true
+}
+
+// normalizeFilePath strips "docs/" directory segments and the .md extension
+// to produce a path comparable to help_urls.h URL paths.
+// e.g. "language-reference-guide/docs/symbols/iota.md" → "language-reference-guide/symbols/iota"
+func normalizeFilePath(file string) string {
+	// Remove /docs/ segment (subsites have subsite/docs/path.md)
+	file = strings.ReplaceAll(file, "/docs/", "/")
+	// Strip leading docs/ for top-level files
+	file = strings.TrimPrefix(file, "docs/")
+	// Strip .md extension
+	file = strings.TrimSuffix(file, ".md")
+	// Strip trailing /index (index.md pages)
+	file = strings.TrimSuffix(file, "/index")
+	return file
+}
+
+type helpURLEntry struct {
+	symbol string
+	url    string // expanded URL path
+}
+
+// parseSymbolURLs reads a JSON file of [{symbol, url}] entries.
+func parseSymbolURLs(path string) ([]helpURLEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var raw []struct {
+		Symbol string `json:"symbol"`
+		URL    string `json:"url"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	entries := make([]helpURLEntry, len(raw))
+	for i, r := range raw {
+		entries[i] = helpURLEntry{symbol: r.Symbol, url: r.URL}
+	}
+	return entries, nil
+}
+
+// matchHelpURL tries to match a help URL path to a doc entry's file path.
+func matchHelpURL(url string, fileIndex map[string]string) (string, bool) {
+	// Direct match
+	if navPath, ok := fileIndex[url]; ok {
+		return navPath, true
+	}
+
+	// Try with /index suffix (section pages)
+	if navPath, ok := fileIndex[url+"/index"]; ok {
+		return navPath, true
+	}
+
+	// Partial suffix match: find the entry whose normalized file path ends with the URL
+	for filePath, navPath := range fileIndex {
+		if strings.HasSuffix(filePath, "/"+url) || filePath == url {
+			return navPath, true
+		}
+	}
+
+	return "", false
+}
+
+// findHelpFile locates a markdown file in fsys for a help URL path that
+// isn't in the mkdocs nav. These are disambiguation pages.
+// Returns (navPath, relFilePath, title, keywords, content, ok).
+func findHelpFile(fsys fs.FS, url string, cache *parseCache, blobSHAs map[string]string) (string, string, string, string, string, bool) {
+	// The URL is like "language-reference-guide/symbols/iota"
+	// The file would be at "language-reference-guide/docs/symbols/iota.md"
+	// or "language-reference-guide/docs/symbols/iota/index.md"
+	parts := strings.SplitN(url, "/", 2)
+	if len(parts) < 2 {
+		return "", "", "", "", "", false
+	}
+	subsite := parts[0]
+	rest := parts[1]
+
+	candidates := []string{
+		path.Join(subsite, "docs", rest+".md"),
+		path.Join(subsite, "docs", rest, "index.md"),
+	}
+
+	for _, candidate := range candidates {
+		if _, err := fs.Stat(fsys, candidate); err != nil {
+			continue
+		}
+		title, keywords, content, ok := parseFileCached(fsys, candidate, cache, blobSHAs)
+		if !ok {
+			continue
+		}
+		// Build a synthetic nav path from the URL segments
+		navPath := buildNavPath(url)
+		if title == "" {
+			// Use last URL segment as fallback
+			urlParts := strings.Split(url, "/")
+			title = urlParts[len(urlParts)-1]
+		}
+		return navPath, candidate, title, keywords, content, true
+	}
+
+	return "", "", "", "", "", false
+}
+
+// extractTitleAndClean is defined in markdown.go: it parses the file with
+// goldmark and golang.org/x/net/html rather than a regex cleanup pipeline.
+
+// buildNavPath creates a readable nav path from a URL like
+// "language-reference-guide/symbols/iota" → "Language Reference Guide / Symbols / Iota"
+func buildNavPath(url string) string {
+	parts := strings.Split(url, "/")
+	for i, p := range parts {
+		// Title-case each segment, replacing hyphens with spaces
+		words := strings.Split(p, "-")
+		for j, w := range words {
+			if len(w) > 0 {
+				words[j] = strings.ToUpper(w[:1]) + w[1:]
+			}
+		}
+		parts[i] = strings.Join(words, " ")
+	}
+	return strings.Join(parts, " / ")
+}
+
+// mirrorDir is the path to the persistent bare clone inside a -cache directory.
+func mirrorDir(cacheDir string) string {
+	return filepath.Join(cacheDir, "repo.git")
+}
+
+// updateMirror brings the bare clone at mirrorDir(cacheDir) up to date with
+// repo, creating it on first use, so subsequent builds only fetch new
+// commits instead of re-cloning the whole history.
+func updateMirror(cacheDir, repo string) error {
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return err
+	}
+	dir := mirrorDir(cacheDir)
+	if _, err := os.Stat(filepath.Join(dir, "HEAD")); err == nil {
+		fmt.Fprintf(os.Stderr, "Fetching updates into %s...\n", dir)
+		cmd := exec.Command("git", "-C", dir, "fetch", "origin", "main:main")
+		cmd.Stderr = os.Stderr
+		return cmd.Run()
+	}
+
+	fmt.Fprintf(os.Stderr, "Creating cache clone of %s in %s...\n", repo, dir)
+	cmd := exec.Command("git", "clone", "--bare", "--branch=main", "--single-branch", repo, dir)
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
+
+// gitBlobSHAs returns the current blob SHA for every tracked file in repoDir,
+// keyed by path relative to repoDir, by parsing `git ls-tree -r HEAD`.
+func gitBlobSHAs(repoDir string) (map[string]string, error) {
+	cmd := exec.Command("git", "-C", repoDir, "ls-tree", "-r", "HEAD")
+	out, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git ls-tree: %w", err)
+	}
+
+	shas := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(string(out)))
+	for scanner.Scan() {
+		// Each line: "<mode> blob <sha>\t<path>"
+		line := scanner.Text()
+		tab := strings.IndexByte(line, '\t')
+		if tab < 0 {
+			continue
+		}
+		fields := strings.Fields(line[:tab])
+		if len(fields) != 3 {
+			continue
+		}
+		shas[line[tab+1:]] = fields[2]
+	}
+	return shas, scanner.Err()
+}
+
+// parseCache is a sidecar sqlite database, kept alongside the bare clone in
+// a -cache directory, that maps a repo path + git blob SHA to the
+// previously extracted (title, keywords, content) for that file. A nil
+// *parseCache is valid and behaves as an always-miss, no-op cache so
+// callers don't need to branch on whether -cache was passed.
+type parseCache struct {
+	db *sql.DB
+}
+
+func openParseCache(path string) (*parseCache, error) {
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.Exec(`
+		CREATE TABLE IF NOT EXISTS meta (
+			path TEXT NOT NULL,
+			blob_sha TEXT NOT NULL,
+			title TEXT NOT NULL,
+			keywords TEXT NOT NULL,
+			content TEXT NOT NULL,
+			PRIMARY KEY (path, blob_sha)
+		);
+		CREATE TABLE IF NOT EXISTS build_state (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		);
+		CREATE TABLE IF NOT EXISTS help_docs (
+			url TEXT PRIMARY KEY,
+			nav_path TEXT NOT NULL,
+			file TEXT NOT NULL,
+			blob_sha TEXT NOT NULL,
+			title TEXT NOT NULL,
+			keywords TEXT NOT NULL,
+			content TEXT NOT NULL
+		);
+	`); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &parseCache{db: db}, nil
+}
+
+func (c *parseCache) Close() error {
+	if c == nil {
+		return nil
+	}
+	return c.db.Close()
+}
+
+// get returns the cached (title, keywords, content) for path at blob_sha, if any.
+func (c *parseCache) get(path, blobSHA string) (title, keywords, content string, ok bool) {
+	if c == nil {
+		return "", "", "", false
+	}
+	err := c.db.QueryRow(
+		"SELECT title, keywords, content FROM meta WHERE path = ? AND blob_sha = ?",
+		path, blobSHA,
+	).Scan(&title, &keywords, &content)
+	if err != nil {
+		return "", "", "", false
+	}
+	return title, keywords, content, true
+}
+
+// put stores the parsed result for path at blob_sha, replacing any entry
+// left over from a previous blob SHA for the same path.
+func (c *parseCache) put(path, blobSHA, title, keywords, content string) {
+	if c == nil {
+		return
+	}
+	if _, err := c.db.Exec("DELETE FROM meta WHERE path = ? AND blob_sha != ?", path, blobSHA); err != nil {
+		log.Printf("warning: pruning parse cache for %s: %v", path, err)
+	}
+	if _, err := c.db.Exec(
+		"INSERT OR REPLACE INTO meta (path, blob_sha, title, keywords, content) VALUES (?, ?, ?, ?, ?)",
+		path, blobSHA, title, keywords, content,
+	); err != nil {
+		log.Printf("warning: writing parse cache for %s: %v", path, err)
+	}
+}
+
+// getHelpDoc returns the cached disambiguation-page resolution for url, if
+// any, but only when its file's blob SHA in blobSHAs still matches the SHA
+// it was resolved at - so a stale cache entry for a file that has since
+// changed is treated as a miss, not served.
+func (c *parseCache) getHelpDoc(url string, blobSHAs map[string]string) (navPath, file, title, keywords, content string, ok bool) {
+	if c == nil {
+		return "", "", "", "", "", false
+	}
+	var blobSHA string
+	err := c.db.QueryRow(
+		"SELECT nav_path, file, blob_sha, title, keywords, content FROM help_docs WHERE url = ?",
+		url,
+	).Scan(&navPath, &file, &blobSHA, &title, &keywords, &content)
+	if err != nil {
+		return "", "", "", "", "", false
+	}
+	if blobSHAs[file] != blobSHA {
+		return "", "", "", "", "", false
+	}
+	return navPath, file, title, keywords, content, true
+}
+
+// putHelpDoc stores a disambiguation-page resolution for url, keyed by the
+// blob SHA of the file it came from, so getHelpDoc can tell a later build
+// whether that file has changed since.
+func (c *parseCache) putHelpDoc(url, navPath, file, blobSHA, title, keywords, content string) error {
+	if c == nil {
+		return nil
+	}
+	_, err := c.db.Exec(
+		"INSERT OR REPLACE INTO help_docs (url, nav_path, file, blob_sha, title, keywords, content) VALUES (?, ?, ?, ?, ?, ?, ?)",
+		url, navPath, file, blobSHA, title, keywords, content,
+	)
+	return err
+}
+
+// helpURLsChanged reports whether the file at path has a different sha1
+// than the one recorded by the last recordHelpURLs call, so callers can
+// tell whether help_urls.h itself changed since the previous build.
+func (c *parseCache) helpURLsChanged(path string) (bool, error) {
+	if c == nil {
+		return true, nil
+	}
+	sha, err := fileSHA1(path)
+	if err != nil {
+		return true, err
+	}
+	var prev string
+	err = c.db.QueryRow("SELECT value FROM build_state WHERE key = 'help_urls_sha1'").Scan(&prev)
+	if err == sql.ErrNoRows {
+		return true, nil
+	}
+	if err != nil {
+		return true, err
+	}
+	return prev != sha, nil
+}
+
+// recordHelpURLs stores the current sha1 of path for the next helpURLsChanged check.
+func (c *parseCache) recordHelpURLs(path string) error {
+	if c == nil {
+		return nil
+	}
+	sha, err := fileSHA1(path)
+	if err != nil {
+		return err
+	}
+	_, err = c.db.Exec(
+		"INSERT OR REPLACE INTO build_state (key, value) VALUES ('help_urls_sha1', ?)", sha,
+	)
+	return err
+}
+
+func fileSHA1(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	sum := sha1.Sum(data)
+	return hex.EncodeToString(sum[:]), nil
+}
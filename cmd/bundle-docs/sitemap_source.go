@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// sitemapSource enumerates an already-built static site (mkdocs,
+// Jupyter Book, or similar) by parsing its sitemap.xml and reading each
+// listed page's HTML directly off disk, rather than re-rendering from
+// source markdown.
+type sitemapSource struct {
+	dir string
+}
+
+func (s *sitemapSource) Fetch(ctx context.Context) (fs.FS, error) {
+	return os.DirFS(s.dir), nil
+}
+
+type sitemapURLSet struct {
+	XMLName xml.Name       `xml:"urlset"`
+	URLs    []sitemapEntry `xml:"url"`
+}
+
+type sitemapEntry struct {
+	Loc string `xml:"loc"`
+}
+
+func (s *sitemapSource) Enumerate(fsys fs.FS) ([]docEntry, error) {
+	raw, err := fs.ReadFile(fsys, "sitemap.xml")
+	if err != nil {
+		return nil, fmt.Errorf("reading sitemap.xml: %w", err)
+	}
+	var set sitemapURLSet
+	if err := xml.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("parsing sitemap.xml: %w", err)
+	}
+
+	var docs []docEntry
+	for _, u := range set.URLs {
+		relFile, urlPath, err := sitemapURLToFile(u.Loc)
+		if err != nil {
+			log.Printf("warning: %s: %v", u.Loc, err)
+			continue
+		}
+		page, err := fs.ReadFile(fsys, relFile)
+		if err != nil {
+			log.Printf("warning: %s: %v", relFile, err)
+			continue
+		}
+		title, content := extractSitemapPage(page)
+		if title == "" {
+			title = buildNavPath(urlPath)
+		}
+		docs = append(docs, docEntry{
+			path:    buildNavPath(urlPath),
+			file:    relFile,
+			title:   title,
+			content: content,
+			source:  "sitemap",
+		})
+	}
+	return docs, nil
+}
+
+// sitemapURLToFile maps a sitemap <loc> URL to the built HTML file it
+// corresponds to on disk, and to the URL path used to derive a nav
+// breadcrumb.
+func sitemapURLToFile(loc string) (relFile, urlPath string, err error) {
+	u, err := url.Parse(loc)
+	if err != nil {
+		return "", "", err
+	}
+	urlPath = strings.Trim(u.Path, "/")
+	switch {
+	case u.Path == "" || strings.HasSuffix(u.Path, "/"):
+		relFile = urlPath + "/index.html"
+		relFile = strings.TrimPrefix(relFile, "/")
+	case strings.HasSuffix(urlPath, ".html"):
+		relFile = urlPath
+	default:
+		relFile = urlPath + ".html"
+	}
+	return relFile, urlPath, nil
+}
+
+var (
+	sitemapTitleRe = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+	sitemapMainRe  = regexp.MustCompile(`(?is)<(?:main|article)[^>]*>(.*?)</(?:main|article)>`)
+	sitemapTagRe   = regexp.MustCompile(`(?s)<[^>]+>`)
+)
+
+// extractSitemapPage pulls the <title> and a plain-text rendering of the
+// <main>/<article> content (falling back to the whole document) out of a
+// built HTML page.
+func extractSitemapPage(raw []byte) (title, content string) {
+	s := string(raw)
+	if m := sitemapTitleRe.FindStringSubmatch(s); m != nil {
+		title = strings.TrimSpace(sitemapTagRe.ReplaceAllString(m[1], ""))
+	}
+
+	body := s
+	if m := sitemapMainRe.FindStringSubmatch(s); m != nil {
+		body = m[1]
+	}
+	body = sitemapTagRe.ReplaceAllString(body, " ")
+	content = strings.Join(strings.Fields(body), " ")
+	return title, content
+}
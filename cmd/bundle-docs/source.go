@@ -0,0 +1,32 @@
+package main
+
+import (
+	"context"
+	"io/fs"
+)
+
+// docEntry is one document produced by a Source, ready to be written to
+// the docs table.
+type docEntry struct {
+	path     string // nav/section breadcrumb
+	file     string // path relative to the source's root, for display/debugging
+	title    string // document title
+	keywords string // search keywords from hidden div / front-matter
+	content  string
+	exclude  bool   // true for disambiguation pages
+	source   string // adapter that produced this entry, e.g. "mkdocs", "hugo"
+}
+
+// Source adapts a documentation corpus - a git repo, a plain directory, a
+// built static site - into a flat list of docEntry for indexing. Fetch
+// obtains the corpus (cloning, or simply opening a local directory) and
+// Enumerate walks the resulting filesystem to produce entries. Keeping the
+// two separate lets callers fetch once and retry enumeration, and lets
+// each adapter choose how to access its corpus (git clone vs. a bare
+// directory) independently of how it's walked.
+type Source interface {
+	// Fetch returns a filesystem rooted at the corpus to enumerate.
+	Fetch(ctx context.Context) (fs.FS, error)
+	// Enumerate walks fsys (as returned by Fetch) and returns its documents.
+	Enumerate(fsys fs.FS) ([]docEntry, error)
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// sphinxSource enumerates a Sphinx/RST documentation tree by starting at
+// index.rst and following .. toctree:: directives, converting each page's
+// RST to markdown via a small subset converter.
+type sphinxSource struct {
+	dir string
+}
+
+func (s *sphinxSource) Fetch(ctx context.Context) (fs.FS, error) {
+	return os.DirFS(s.dir), nil
+}
+
+func (s *sphinxSource) Enumerate(fsys fs.FS) ([]docEntry, error) {
+	var docs []docEntry
+	visited := make(map[string]bool)
+	if err := walkToctree(fsys, "index", nil, visited, &docs); err != nil {
+		return nil, err
+	}
+	return docs, nil
+}
+
+// walkToctree reads docname+".rst", converts it, appends it to out, and
+// recurses into the doc names listed in its own toctree directives.
+// breadcrumb is the nav path prefix inherited from the parent document.
+func walkToctree(fsys fs.FS, docname string, breadcrumb []string, visited map[string]bool, out *[]docEntry) error {
+	if visited[docname] {
+		return nil
+	}
+	visited[docname] = true
+
+	relFile := docname + ".rst"
+	raw, err := fs.ReadFile(fsys, relFile)
+	if err != nil {
+		return fmt.Errorf("%s: %w", relFile, err)
+	}
+
+	title, content := rstToMarkdown(raw)
+	if title == "" {
+		title = docname
+	}
+	crumb := append(append([]string{}, breadcrumb...), title)
+
+	*out = append(*out, docEntry{
+		path:    strings.Join(crumb, " / "),
+		file:    relFile,
+		title:   title,
+		content: content,
+		source:  "sphinx",
+	})
+
+	for _, child := range parseToctree(raw) {
+		if err := walkToctree(fsys, child, crumb, visited, out); err != nil {
+			log.Printf("warning: toctree entry %s: %v", child, err)
+		}
+	}
+	return nil
+}
+
+var toctreeStartRe = regexp.MustCompile(`^\.\.\s+toctree::\s*$`)
+
+// parseToctree extracts the doc names listed under .. toctree:: directives
+// in an RST file, skipping toctree options like ":maxdepth: 2".
+func parseToctree(raw []byte) []string {
+	var children []string
+	inBlock := false
+	for _, line := range strings.Split(string(raw), "\n") {
+		if toctreeStartRe.MatchString(line) {
+			inBlock = true
+			continue
+		}
+		if !inBlock {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if !strings.HasPrefix(line, " ") && !strings.HasPrefix(line, "\t") {
+			inBlock = false
+			continue
+		}
+		if strings.HasPrefix(trimmed, ":") {
+			continue // toctree option, e.g. :maxdepth: 2
+		}
+		children = append(children, trimmed)
+	}
+	return children
+}
+
+// rstToMarkdown converts the RST subset bundle-docs cares about - section
+// headings (underlined text) and double-backtick code spans - to
+// markdown, and returns the first heading found as the title.
+func rstToMarkdown(raw []byte) (title, markdown string) {
+	lines := strings.Split(string(raw), "\n")
+	adornmentLevel := make(map[byte]int)
+	nextLevel := 1
+
+	var out []string
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		if i+1 < len(lines) && isRSTUnderline(lines[i+1], line) {
+			ch := strings.TrimSpace(lines[i+1])[0]
+			lvl, ok := adornmentLevel[ch]
+			if !ok {
+				lvl = nextLevel
+				adornmentLevel[ch] = lvl
+				nextLevel++
+			}
+			heading := strings.TrimSpace(line)
+			if title == "" {
+				title = heading
+			}
+			out = append(out, strings.Repeat("#", lvl)+" "+heading)
+			i++ // also consume the underline
+			continue
+		}
+		out = append(out, rstInlineToMarkdown(line))
+	}
+	return title, strings.Join(out, "\n")
+}
+
+// isRSTUnderline reports whether underline is a valid RST section
+// adornment line for heading.
+func isRSTUnderline(underline, heading string) bool {
+	u := strings.TrimSpace(underline)
+	h := strings.TrimSpace(heading)
+	if u == "" || h == "" || len(u) < len(h) {
+		return false
+	}
+	ch := u[0]
+	if !strings.ContainsRune(`=-~^"'#*+.:_`, rune(ch)) {
+		return false
+	}
+	for i := 0; i < len(u); i++ {
+		if u[i] != ch {
+			return false
+		}
+	}
+	return true
+}
+
+// rstInlineToMarkdown converts double-backtick RST code spans to the
+// single-backtick markdown equivalent; other RST inline markup
+// (*emphasis*, **strong**) is already valid markdown as-is.
+func rstInlineToMarkdown(line string) string {
+	return strings.ReplaceAll(line, "``", "`")
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRstToMarkdown(t *testing.T) {
+	raw := "Iota\n" +
+		"====\n" +
+		"\n" +
+		"Returns ``⍳`` the index generator.\n" +
+		"\n" +
+		"Subsection\n" +
+		"----------\n" +
+		"\n" +
+		"More text.\n"
+
+	title, markdown := rstToMarkdown([]byte(raw))
+
+	if title != "Iota" {
+		t.Errorf("title = %q, want %q", title, "Iota")
+	}
+	for _, want := range []string{"# Iota", "## Subsection", "`⍳`", "More text."} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("markdown = %q, want to contain %q", markdown, want)
+		}
+	}
+	if strings.Contains(markdown, "``") {
+		t.Errorf("markdown = %q, double-backtick code span should have become single-backtick", markdown)
+	}
+}
+
+func TestIsRSTUnderline(t *testing.T) {
+	tests := []struct {
+		name      string
+		underline string
+		heading   string
+		want      bool
+	}{
+		{"matches heading length with =", "====", "Iota", true},
+		{"longer underline than heading is fine", "======", "Iota", true},
+		{"shorter underline than heading", "==", "Iota", false},
+		{"not a repeated adornment char", "=-=-", "Iota", false},
+		{"empty heading", "====", "", false},
+		{"empty underline", "", "Iota", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRSTUnderline(tt.underline, tt.heading); got != tt.want {
+				t.Errorf("isRSTUnderline(%q, %q) = %v, want %v", tt.underline, tt.heading, got, tt.want)
+			}
+		})
+	}
+}
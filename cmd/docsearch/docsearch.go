@@ -0,0 +1,211 @@
+// docsearch queries the Dyalog documentation database.
+//
+//	go build -tags "fts5" -o docsearch ./cmd/docsearch
+package main
+
+import (
+	"bufio"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	dbPath := flag.String("d", "./dyalog-docs.db", "database path")
+	search := flag.String("s", "", "search string (use '-' to read from stdin)")
+	rowid := flag.Int64("r", 0, "fetch document by rowid")
+	limit := flag.Int("l", 10, "maximum number of results")
+	source := flag.String("source", "", "restrict results to one ingestion source (e.g. mkdocs, hugo)")
+	chunks := flag.Bool("chunks", false, "search per-section chunks instead of whole documents")
+	flag.Parse()
+
+	if *search == "" && *rowid == 0 {
+		fmt.Fprintln(os.Stderr, "Usage: docsearch -s <search> | -r <rowid>")
+		fmt.Fprintln(os.Stderr, "  -d <database>  Database path (default: ./dyalog-docs.db)")
+		fmt.Fprintln(os.Stderr, "  -s <string>    Search string (use '-' to read from stdin)")
+		fmt.Fprintln(os.Stderr, "  -r <rowid>     Fetch document by rowid")
+		fmt.Fprintln(os.Stderr, "  -l <limit>     Maximum number of results (default: 10)")
+		fmt.Fprintln(os.Stderr, "  -source <src>  Restrict results to one ingestion source")
+		fmt.Fprintln(os.Stderr, "  -chunks        Search per-section chunks instead of whole documents")
+		os.Exit(1)
+	}
+
+	db, err := sql.Open("sqlite3", *dbPath)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+
+	if *rowid != 0 {
+		fetchByRowid(db, *rowid)
+		return
+	}
+
+	query := *search
+	if query == "-" {
+		scanner := bufio.NewScanner(os.Stdin)
+		if scanner.Scan() {
+			query = scanner.Text()
+		}
+		if err := scanner.Err(); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if query == "" {
+		log.Fatal("empty search string")
+	}
+
+	if *chunks {
+		searchChunks(db, query, *limit, *source)
+		return
+	}
+	searchDocs(db, query, *limit, *source)
+}
+
+func fetchByRowid(db *sql.DB, rowid int64) {
+	var content string
+	err := db.QueryRow("SELECT content FROM docs WHERE rowid = ?", rowid).Scan(&content)
+	if err == sql.ErrNoRows {
+		log.Fatalf("no document with rowid %d", rowid)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(content)
+}
+
+// Score weights for the hybrid ranking in searchDocs. bm25() is negated so
+// that, like the other terms, a higher contribution means a better match.
+const (
+	weightBM25        = 1.0 // per FTS5 bm25(), already column-weighted below
+	weightSymbolMatch = 8.0 // exact match against a help_urls symbol
+	weightKeywordLike = 3.0 // keyword LIKE match
+	weightTitlePrefix = 4.0 // title starts with the query
+)
+
+// searchDocs runs a single ranked hybrid query combining FTS5 bm25() over
+// title/keywords/content (column-weighted 10/5/1), an exact-match bonus
+// against help_urls symbols, a keyword LIKE bonus, and a title-prefix
+// bonus, and prints rowid, score and title ordered by descending score.
+// If source is non-empty, results are restricted to docs from that
+// ingestion source.
+func searchDocs(db *sql.DB, query string, limit int, source string) {
+	ftsQuery := preprocessQuery(db, query)
+
+	// source filters each branch via "d.source = ? OR ? = ''" so an empty
+	// -source leaves the branch unrestricted without building SQL by hand.
+	rows, err := db.Query(`
+		SELECT rowid, title, SUM(score) AS total FROM (
+			SELECT d.rowid AS rowid, d.title AS title,
+				? * (-bm25(docs_fts, 0.0, 10.0, 5.0, 1.0)) AS score
+			FROM docs_fts
+			JOIN docs d ON d.rowid = docs_fts.rowid
+			WHERE docs_fts MATCH ? AND d.exclude = 0 AND (d.source = ? OR ? = '')
+			UNION ALL
+			SELECT d.rowid, d.title, ? AS score
+			FROM help_urls h
+			JOIN docs d ON d.path = h.path
+			WHERE h.symbol = ? AND d.exclude = 0 AND (d.source = ? OR ? = '')
+			UNION ALL
+			SELECT rowid, title, ? AS score
+			FROM docs WHERE keywords LIKE ? COLLATE NOCASE AND exclude = 0 AND (source = ? OR ? = '')
+			UNION ALL
+			SELECT rowid, title, ? AS score
+			FROM docs WHERE title LIKE ? COLLATE NOCASE AND exclude = 0 AND (source = ? OR ? = '')
+		)
+		GROUP BY rowid, title
+		ORDER BY total DESC
+		LIMIT ?
+	`,
+		weightBM25, ftsQuery, source, source,
+		weightSymbolMatch, query, source, source,
+		weightKeywordLike, "%"+query+"%", source, source,
+		weightTitlePrefix, query+"%", source, source,
+		limit,
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rowid int64
+		var title string
+		var score float64
+		if err := rows.Scan(&rowid, &title, &score); err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("%d %.3f %s\n", rowid, score, title)
+	}
+	if err := rows.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// searchChunks runs an FTS5 bm25() query over per-section chunks
+// (heading_path and content, column-weighted 5/1) and prints each hit as
+// "score docpath#anchor snippet", so a hit can be linked straight back to
+// the heading it came from. If source is non-empty, results are
+// restricted to docs from that ingestion source.
+func searchChunks(db *sql.DB, query string, limit int, source string) {
+	rows, err := db.Query(`
+		SELECT d.path, c.anchor,
+			bm25(chunks_fts, 5.0, 1.0) AS score,
+			snippet(chunks_fts, 1, '<mark>', '</mark>', '…', 12)
+		FROM chunks_fts
+		JOIN chunks c ON c.chunk_id = chunks_fts.rowid
+		JOIN docs d ON d.rowid = c.doc_rowid
+		WHERE chunks_fts MATCH ? AND d.exclude = 0 AND (d.source = ? OR ? = '')
+		ORDER BY score
+		LIMIT ?
+	`, escapeQuery(query), source, source, limit)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var path, anchor, snippet string
+		var score float64
+		if err := rows.Scan(&path, &anchor, &score, &snippet); err != nil {
+			log.Fatal(err)
+		}
+		loc := path
+		if anchor != "" {
+			loc += "#" + anchor
+		}
+		fmt.Printf("%.3f %s %s\n", score, loc, snippet)
+	}
+	if err := rows.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// preprocessQuery expands a query that is itself a known APL glyph symbol
+// (e.g. "⍳") into an FTS5 OR of the glyph and the title of the doc its
+// help_urls entry resolves to, so glyph searches also match by name.
+func preprocessQuery(db *sql.DB, query string) string {
+	var title string
+	err := db.QueryRow(`
+		SELECT d.title FROM help_urls h
+		JOIN docs d ON d.path = h.path
+		WHERE h.symbol = ?
+	`, query).Scan(&title)
+	if err != nil {
+		return escapeQuery(query)
+	}
+	return escapeQuery(query) + " OR " + escapeQuery(title)
+}
+
+// escapeQuery wraps the query in quotes to handle special characters
+func escapeQuery(q string) string {
+	// Escape double quotes by doubling them
+	q = strings.ReplaceAll(q, `"`, `""`)
+	return `"` + q + `"`
+}
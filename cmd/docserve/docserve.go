@@ -0,0 +1,176 @@
+// docserve opens the Dyalog documentation database read-only and exposes
+// it as a small JSON HTTP API, for use as a backend for editor plugins
+// and chat-style docs UIs.
+//
+//	go build -tags "fts5" -o docserve ./cmd/docserve
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"flag"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+func main() {
+	dbPath := flag.String("d", "./dyalog-docs.db", "database path")
+	addr := flag.String("addr", ":8080", "listen address")
+	flag.Parse()
+
+	db, err := sql.Open("sqlite3", "file:"+*dbPath+"?mode=ro")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer db.Close()
+	if err := db.Ping(); err != nil {
+		log.Fatalf("opening %s: %v", *dbPath, err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/search", handleSearch(db))
+	mux.HandleFunc("/doc/", handleDoc(db))
+	mux.HandleFunc("/symbol/", handleSymbol(db))
+
+	log.Printf("docserve listening on %s (db: %s)", *addr, *dbPath)
+	log.Fatal(http.ListenAndServe(*addr, mux))
+}
+
+// searchHit is one ranked result from /search.
+type searchHit struct {
+	Rowid   int64   `json:"rowid"`
+	Path    string  `json:"path"`
+	Title   string  `json:"title"`
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet"`
+}
+
+func handleSearch(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		if q == "" {
+			http.Error(w, "missing q parameter", http.StatusBadRequest)
+			return
+		}
+		limit := 10
+		if l := r.URL.Query().Get("limit"); l != "" {
+			n, err := strconv.Atoi(l)
+			if err != nil || n <= 0 {
+				http.Error(w, "invalid limit parameter", http.StatusBadRequest)
+				return
+			}
+			limit = n
+		}
+		source := r.URL.Query().Get("source")
+
+		rows, err := db.Query(`
+			SELECT d.rowid, d.path, d.title,
+				bm25(docs_fts, 0.0, 10.0, 5.0, 1.0) AS score,
+				snippet(docs_fts, 3, '<mark>', '</mark>', '…', 12)
+			FROM docs_fts
+			JOIN docs d ON d.rowid = docs_fts.rowid
+			WHERE docs_fts MATCH ? AND d.exclude = 0 AND (d.source = ? OR ? = '')
+			ORDER BY score
+			LIMIT ?
+		`, escapeQuery(q), source, source, limit)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		defer rows.Close()
+
+		hits := []searchHit{}
+		for rows.Next() {
+			var h searchHit
+			if err := rows.Scan(&h.Rowid, &h.Path, &h.Title, &h.Score, &h.Snippet); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			hits = append(hits, h)
+		}
+		if err := rows.Err(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, hits)
+	}
+}
+
+func handleDoc(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		rowid, err := strconv.ParseInt(strings.TrimPrefix(r.URL.Path, "/doc/"), 10, 64)
+		if err != nil {
+			http.Error(w, "invalid rowid", http.StatusBadRequest)
+			return
+		}
+
+		var path, title, content string
+		err = db.QueryRow("SELECT path, title, content FROM docs WHERE rowid = ?", rowid).
+			Scan(&path, &title, &content)
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, struct {
+			Rowid   int64  `json:"rowid"`
+			Path    string `json:"path"`
+			Title   string `json:"title"`
+			Content string `json:"content"`
+		}{rowid, path, title, content})
+	}
+}
+
+func handleSymbol(db *sql.DB) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sym := strings.TrimPrefix(r.URL.Path, "/symbol/")
+		if sym == "" {
+			http.Error(w, "missing symbol", http.StatusBadRequest)
+			return
+		}
+
+		var rowid int64
+		var path, title string
+		err := db.QueryRow(`
+			SELECT d.rowid, d.path, d.title FROM help_urls h
+			JOIN docs d ON d.path = h.path
+			WHERE h.symbol = ?
+		`, sym).Scan(&rowid, &path, &title)
+		if err == sql.ErrNoRows {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, struct {
+			Rowid int64  `json:"rowid"`
+			Path  string `json:"path"`
+			Title string `json:"title"`
+		}{rowid, path, title})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("encoding response: %v", err)
+	}
+}
+
+// escapeQuery wraps the query in quotes to handle special characters.
+func escapeQuery(q string) string {
+	q = strings.ReplaceAll(q, `"`, `""`)
+	return `"` + q + `"`
+}